@@ -0,0 +1,36 @@
+/*
+Package cmd includes relayer commands
+Copyright © 2020 Jack Zampolin <jack.zampolin@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import "github.com/spf13/cobra"
+
+// chainsCmd represents the chains command, a parent for subcommands that
+// inspect or modify the chains in the config file.
+func chainsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "chains",
+		Aliases: []string{"ch"},
+		Short:   "Manage chain configurations",
+	}
+
+	cmd.AddCommand(
+		chainsHealthCmd(),
+		chainsAddFromRegistryCmd(),
+	)
+
+	return cmd
+}
@@ -0,0 +1,73 @@
+/*
+Package cmd includes relayer commands
+Copyright © 2020 Jack Zampolin <jack.zampolin@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cosmos/relayer/relayer"
+	"github.com/spf13/cobra"
+)
+
+const (
+	flagRegistryURL = "registry-url"
+	flagChainKey    = "key"
+)
+
+// chainsAddFromRegistryCmd represents the chains add-from-registry command
+func chainsAddFromRegistryCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "add-from-registry [chain-name]",
+		Aliases: []string{"afr"},
+		Short:   "Add a chain to the config by pulling its chain.json from the cosmos chain-registry",
+		Args:    cobra.ExactArgs(1),
+		Example: strings.TrimSpace(fmt.Sprintf(`
+$ %s chains add-from-registry osmosis
+$ %s chains add-from-registry cosmoshub --key cosmos-key`, appName, appName)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			registryURL, err := cmd.Flags().GetString(flagRegistryURL)
+			if err != nil {
+				return err
+			}
+			key, err := cmd.Flags().GetString(flagChainKey)
+			if err != nil {
+				return err
+			}
+			if key == "" {
+				key = args[0]
+			}
+
+			rc := relayer.NewRegistryClient(registryURL)
+			cc, err := rc.ChainConfigFromRegistry(context.Background(), args[0], key)
+			if err != nil {
+				return fmt.Errorf("fetching %s from chain-registry: %w", args[0], err)
+			}
+
+			if err := config.AddChain(cc); err != nil {
+				return err
+			}
+
+			fmt.Printf("chain %s added with RPC endpoint %s\n", cc.ChainID, cc.RPCAddr)
+			return overWriteConfig(config)
+		},
+	}
+	cmd.Flags().String(flagRegistryURL, relayer.DefaultRegistryURL, "base URL of the chain-registry to pull chain.json from")
+	cmd.Flags().String(flagChainKey, "", "key name to use for this chain in the config (defaults to the chain name)")
+	return cmd
+}
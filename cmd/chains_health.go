@@ -0,0 +1,112 @@
+/*
+Package cmd includes relayer commands
+Copyright © 2020 Jack Zampolin <jack.zampolin@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/cosmos/relayer/relayer"
+	"github.com/spf13/cobra"
+)
+
+const (
+	rpcProbeInterval  = time.Second * 30
+	rpcStaleThreshold = time.Minute * 5
+)
+
+// startEndpointObservers starts a chain's balancer health prober (a no-op if
+// the chain has no Balancer, i.e. a single configured RPC address) and
+// mirrors its results into the relayer_rpc_endpoint_healthy gauge until ctx
+// is canceled.
+func startEndpointObservers(ctx context.Context, c *relayer.Chain) {
+	if c.Balancer == nil {
+		return
+	}
+	c.Balancer.StartProbing(ctx, rpcProbeInterval, rpcStaleThreshold)
+
+	go func() {
+		ticker := time.NewTicker(rpcProbeInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				for _, st := range c.Balancer.Statuses() {
+					healthy := 0.0
+					if !st.Unhealthy {
+						healthy = 1
+					}
+					rpcEndpointHealthy.WithLabelValues(c.ChainID, st.URL).Set(healthy)
+				}
+			}
+		}
+	}()
+}
+
+// chainsHealthCmd represents the chains health command
+func chainsHealthCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "health [chain-id]",
+		Aliases: []string{"ha"},
+		Short:   "Print the health of each RPC endpoint configured for a chain (or every chain)",
+		Long: strings.TrimSpace(`Print the health of each RPC endpoint configured for a chain (or every chain).
+
+This command is a one-off process: it has no access to the health history a
+running "start" process has been accumulating via StartProbing, so it probes
+every endpoint itself before printing. The result reflects only this
+invocation's own quick check, not the endpoint's behavior over time. A single
+failed probe shows up under CONSECUTIVE FAILS but does not by itself mark an
+endpoint unhealthy, so HEALTHY stays true for it.`),
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			chains := config.Chains
+			if len(args) == 1 {
+				c, err := config.Chains.Get(args[0])
+				if err != nil {
+					return err
+				}
+				chains = map[string]*relayer.Chain{args[0]: c}
+			}
+
+			for _, c := range chains {
+				if c.Balancer != nil {
+					c.Balancer.ProbeNow(cmd.Context(), rpcStaleThreshold)
+				}
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "CHAIN\tENDPOINT\tHEALTHY\tCONSECUTIVE FAILS")
+			for chainID, c := range chains {
+				if c.Balancer == nil {
+					fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", chainID, c.RPCAddr, "n/a (single endpoint)", "-")
+					continue
+				}
+				for _, st := range c.Balancer.Statuses() {
+					fmt.Fprintf(w, "%s\t%s\t%t\t%d\n", chainID, st.URL, !st.Unhealthy, st.ConsecutiveFails)
+				}
+			}
+			return w.Flush()
+		},
+	}
+	return cmd
+}
@@ -0,0 +1,162 @@
+/*
+Package cmd includes relayer commands
+Copyright © 2020 Jack Zampolin <jack.zampolin@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"time"
+
+	raven "github.com/getsentry/raven-go"
+)
+
+const (
+	flagSentryDSN = "sentry-dsn"
+	envSentryDSN  = "SENTRY_DSN"
+
+	// crashSpoolMaxFiles and crashSpoolMaxBytes bound the on-disk queue so a
+	// long-lived relayer with a flapping chain doesn't fill the disk while
+	// Sentry is unreachable.
+	crashSpoolMaxFiles = 200
+	crashSpoolMaxBytes = 50 * 1024 * 1024
+)
+
+// crashReport is what gets spooled to ~/.relayer/crashes/ and, when Sentry is
+// configured, shipped via raven-go. It survives a Sentry outage: the spool
+// can be replayed once Sentry is reachable again.
+type crashReport struct {
+	ChainID   string    `json:"chain_id,omitempty"`
+	PathName  string    `json:"path_name,omitempty"`
+	Panic     string    `json:"panic"`
+	Stack     string    `json:"stack"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// initCrashReporting configures the raven-go client if dsn (or $SENTRY_DSN)
+// is set. Sentry reporting is entirely optional: if dsn is empty this is a
+// no-op and recoverAndReport still spools crashes to disk.
+func initCrashReporting(dsn string) error {
+	if dsn == "" {
+		dsn = os.Getenv(envSentryDSN)
+	}
+	if dsn == "" {
+		return nil
+	}
+	return raven.SetDSN(dsn)
+}
+
+// recoverAndReport should be deferred at the top of any goroutine started by
+// startCmd, including those started indirectly via an errgroup's Go. On
+// panic it tags the report with chainID/pathName, ships it to Sentry if
+// configured, always spools it to ~/.relayer/crashes/, and then lets the
+// goroutine exit rather than crashing the whole process.
+func recoverAndReport(chainID, pathName string) {
+	if r := recover(); r != nil {
+		reportPanic(chainID, pathName, r)
+	}
+}
+
+// reportPanic does the actual Sentry/spool/log work for a value already
+// obtained from recover(). It's split out from recoverAndReport so call
+// sites that need the panic value for something else (e.g. recording it as
+// the error of an errgroup closure) can recover it themselves and still get
+// the same reporting behavior.
+func reportPanic(chainID, pathName string, r interface{}) {
+	report := crashReport{
+		ChainID:   chainID,
+		PathName:  pathName,
+		Panic:     fmt.Sprintf("%v", r),
+		Stack:     stackTrace(),
+		Timestamp: time.Now(),
+	}
+
+	raven.CaptureMessageAndWait(report.Panic, map[string]string{
+		"chain_id":  chainID,
+		"path_name": pathName,
+	})
+
+	if err := spoolCrashReport(report); err != nil {
+		fmt.Printf("failed to spool crash report: %v\n", err)
+	}
+
+	fmt.Printf("path %s: recovered from panic on chain %s: %v\n", pathName, chainID, r)
+}
+
+// stackTrace captures the current goroutine's stack trace for inclusion in a
+// spooled crash report.
+func stackTrace() string {
+	buf := make([]byte, 1<<16)
+	n := runtime.Stack(buf, false)
+	return string(buf[:n])
+}
+
+func spoolCrashReport(report crashReport) error {
+	dir := filepath.Join(relayerHome(), "crashes")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	name := filepath.Join(dir, fmt.Sprintf("%d.json", report.Timestamp.UnixNano()))
+	if err := os.WriteFile(name, data, 0644); err != nil {
+		return err
+	}
+
+	return pruneCrashSpool(dir)
+}
+
+// pruneCrashSpool deletes the oldest spooled reports until the spool is
+// within crashSpoolMaxFiles and crashSpoolMaxBytes.
+func pruneCrashSpool(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+
+	var total int64
+	infos := make([]os.FileInfo, len(entries))
+	for i, e := range entries {
+		info, err := e.Info()
+		if err != nil {
+			return err
+		}
+		infos[i] = info
+		total += info.Size()
+	}
+
+	for len(entries) > crashSpoolMaxFiles || total > crashSpoolMaxBytes {
+		oldest := entries[0]
+		info := infos[0]
+		if err := os.Remove(filepath.Join(dir, oldest.Name())); err != nil {
+			return err
+		}
+		entries = entries[1:]
+		infos = infos[1:]
+		total -= info.Size()
+	}
+	return nil
+}
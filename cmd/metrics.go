@@ -0,0 +1,73 @@
+/*
+Package cmd includes relayer commands
+Copyright © 2020 Jack Zampolin <jack.zampolin@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const flagMetricsListen = "metrics-listen"
+
+var (
+	clientUpdateTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "relayer_client_update_total",
+		Help: "Count of client update attempts, labeled by chain and result (success/error).",
+	}, []string{"chain", "result"})
+
+	clientExpirySeconds = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "relayer_client_expiry_seconds",
+		Help: "Remaining time, in seconds, before a chain's counterparty client expires.",
+	}, []string{"chain"})
+
+	// relayer_packets_relayed_total and relayer_tx_broadcast_latency_seconds
+	// are intentionally not defined here: this tree has no packet-relaying or
+	// tx-broadcast code path to observe them from, and shipping unwired
+	// gauges/histograms that always read zero is worse than not shipping them
+	// at all. Add them back alongside the code that actually relays packets.
+
+	rpcEndpointHealthy = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "relayer_rpc_endpoint_healthy",
+		Help: "Whether a chain's RPC endpoint is currently considered healthy (1) or not (0).",
+	}, []string{"chain", "endpoint"})
+)
+
+// startMetricsServer starts an HTTP server exposing the relayer's Prometheus
+// metrics on addr (e.g. ":7597") and returns a function to shut it down.
+func startMetricsServer(addr string) func() {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	srv := &http.Server{Addr: addr, Handler: mux}
+
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			fmt.Printf("metrics server stopped: %v\n", err)
+		}
+	}()
+
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+		defer cancel()
+		_ = srv.Shutdown(ctx)
+	}
+}
@@ -0,0 +1,100 @@
+/*
+Package cmd includes relayer commands
+Copyright © 2020 Jack Zampolin <jack.zampolin@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cosmos/relayer/relayer"
+	"github.com/spf13/cobra"
+)
+
+// pathsFetchCmd represents the paths fetch command
+func pathsFetchCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "fetch",
+		Aliases: []string{"ft"},
+		Short:   "Scan the chain-registry's _IBC/ directory and add paths between chains already in the config",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			registryURL, err := cmd.Flags().GetString(flagRegistryURL)
+			if err != nil {
+				return err
+			}
+
+			rc := relayer.NewRegistryClient(registryURL)
+			ctx := context.Background()
+
+			entries, err := rc.ListIBCPaths(ctx)
+			if err != nil {
+				return err
+			}
+
+			added := 0
+			for _, entry := range entries {
+				name := strings.TrimSuffix(entry, ".json")
+				chain1, chain2, ok := splitIBCFileName(name)
+				if !ok {
+					continue
+				}
+
+				key1, ok1 := config.Chains.KeyForChainName(chain1)
+				key2, ok2 := config.Chains.KeyForChainName(chain2)
+				if !ok1 || !ok2 {
+					// Only materialize paths between chains the operator already configured.
+					continue
+				}
+
+				path, err := rc.PathFromRegistry(ctx, entry, key1, key2)
+				if err != nil {
+					fmt.Printf("skipping %s: %v\n", entry, err)
+					continue
+				}
+
+				pathName := fmt.Sprintf("%s-%s", key1, key2)
+				if err := config.Paths.Add(pathName, path); err != nil {
+					fmt.Printf("skipping %s: %v\n", pathName, err)
+					continue
+				}
+				fmt.Printf("added path %s (%s <-> %s)\n", pathName, key1, key2)
+				added++
+			}
+
+			if added == 0 {
+				fmt.Println("no new paths found between chains already in the config")
+				return nil
+			}
+			return overWriteConfig(config)
+		},
+	}
+	cmd.Flags().String(flagRegistryURL, relayer.DefaultRegistryURL, "base URL of the chain-registry to fetch _IBC/ entries from (the _IBC/ listing itself always comes from the GitHub contents API)")
+	return cmd
+}
+
+// splitIBCFileName splits a chain-registry _IBC file name of the form
+// "<chain-1>-<chain-2>" into its two chain names. Chain names themselves
+// never contain a hyphen in the registry, so the first hyphen is the split
+// point.
+func splitIBCFileName(name string) (chain1, chain2 string, ok bool) {
+	parts := strings.SplitN(name, "-", 2)
+	if len(parts) != 2 {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
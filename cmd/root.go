@@ -0,0 +1,53 @@
+/*
+Package cmd includes relayer commands
+Copyright © 2020 Jack Zampolin <jack.zampolin@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+const appName = "rly"
+
+// NewRootCmd returns the root *cobra.Command for the relayer CLI, with every
+// top-level command wired in.
+func NewRootCmd() *cobra.Command {
+	rootCmd := &cobra.Command{
+		Use:   appName,
+		Short: "This application makes data relaying between IBC enabled chains easy!",
+	}
+
+	rootCmd.AddCommand(
+		startCmd(),
+		statusCmd(),
+		chainsCmd(),
+		pathsCmd(),
+	)
+
+	return rootCmd
+}
+
+// Execute adds all child commands to the root command and runs it. This is
+// called by main.main(); it only needs to happen once.
+func Execute() {
+	if err := NewRootCmd().Execute(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
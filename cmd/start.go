@@ -17,14 +17,16 @@ limitations under the License.
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"math/rand"
 	"os"
 	"os/signal"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
-	retry "github.com/avast/retry-go"
 	"github.com/cosmos/relayer/relayer"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -36,74 +38,138 @@ import (
 // 	defaultTime time.Duration = time.Minute * 1
 // )
 
+const flagAll = "all"
+
 // startCmd represents the start command
 // NOTE: This is basically psuedocode
 func startCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:     "start [path-name]",
+		Use:     "start [path-name...]",
 		Aliases: []string{"st"},
-		Short:   "Start the listening relayer on a given path",
-		Args:    cobra.ExactArgs(1),
+		Short:   "Start the listening relayer on one or more paths",
+		Args:    cobra.ArbitraryArgs,
 		Example: strings.TrimSpace(fmt.Sprintf(`
 $ %s start demo-path --max-msgs 3
-$ %s start demo-path2 --max-tx-size 10`, appName, appName)),
+$ %s start demo-path demo-path2 --max-tx-size 10
+$ %s start --all`, appName, appName, appName)),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			c, src, dst, err := config.ChainsFromPath(args[0])
+			all, err := cmd.Flags().GetBool(flagAll)
 			if err != nil {
 				return err
 			}
 
-			if err = ensureKeysExist(c); err != nil {
+			metricsListen, err := cmd.Flags().GetString(flagMetricsListen)
+			if err != nil {
 				return err
 			}
+			if metricsListen != "" {
+				stopMetrics := startMetricsServer(metricsListen)
+				defer stopMetrics()
+			}
 
-			path := config.Paths.MustGet(args[0])
-			strategy, err := GetStrategyWithOptions(cmd, path.MustGetStrategy())
+			sentryDSN, err := cmd.Flags().GetString(flagSentryDSN)
 			if err != nil {
 				return err
 			}
+			if err := initCrashReporting(sentryDSN); err != nil {
+				return fmt.Errorf("configuring sentry: %w", err)
+			}
 
-			if relayer.SendToController != nil {
-				action := relayer.PathAction{
-					Path: path,
-					Type: "RELAYER_PATH_START",
+			pathNames := args
+			if all {
+				if len(args) > 0 {
+					return fmt.Errorf("cannot pass path names alongside --%s", flagAll)
 				}
-				cont, err := relayer.ControllerUpcall(&action)
-				if !cont {
-					return err
+				for name := range config.Paths {
+					pathNames = append(pathNames, name)
 				}
 			}
+			if len(pathNames) == 0 {
+				return fmt.Errorf("must specify at least one path name, or pass --%s", flagAll)
+			}
 
-			done, err := relayer.RunStrategy(c[src], c[dst], strategy)
-			if err != nil {
+			ctx, cancel := context.WithCancel(context.Background())
+
+			var (
+				donesMu sync.Mutex
+				dones   []func()
+				wg      sync.WaitGroup
+			)
+
+			// cleanup cancels every path's supervisor, calls the done() func
+			// RunStrategy handed back for each path that had already started,
+			// and waits for their goroutines to exit. It must run on every
+			// return path out of RunE, not just the one reached via
+			// trapSignal -- otherwise paths that started successfully before
+			// a later path failed to start would be left running with
+			// nothing to ever call their done().
+			cleanup := func(err error) error {
+				cancel()
+				donesMu.Lock()
+				ds := dones
+				donesMu.Unlock()
+				for _, done := range ds {
+					done()
+				}
+				wg.Wait()
 				return err
 			}
 
-			eg := new(errgroup.Group)
-			eg.Go(func() error {
-				for {
-					var sleepTime time.Duration
-					if err := retry.Do(func() error {
-						sleepTime, err = UpdateClientsFromChains(c[src], c[dst])
-						if err != nil {
-							return err
-						}
-						return nil
-					}, retry.Attempts(5), retry.Delay(time.Millisecond*500), retry.LastErrorOnly(true)); err != nil {
-						return err
+			for _, pathName := range pathNames {
+				c, src, dst, err := config.ChainsFromPath(pathName)
+				if err != nil {
+					return cleanup(err)
+				}
+
+				if err = ensureKeysExist(c); err != nil {
+					return cleanup(err)
+				}
+
+				path := config.Paths.MustGet(pathName)
+				strategy, err := GetStrategyWithOptions(cmd, path.MustGetStrategy())
+				if err != nil {
+					return cleanup(err)
+				}
+
+				if relayer.SendToController != nil {
+					action := relayer.PathAction{
+						Path: path,
+						Type: "RELAYER_PATH_START",
+					}
+					cont, err := relayer.ControllerUpcall(&action)
+					if !cont {
+						return cleanup(err)
 					}
-					time.Sleep(sleepTime)
 				}
-			})
 
-			if err = eg.Wait(); err != nil {
-				return err
+				done, err := relayer.RunStrategy(c[src], c[dst], strategy)
+				if err != nil {
+					return cleanup(err)
+				}
+
+				startEndpointObservers(ctx, c[src])
+				startEndpointObservers(ctx, c[dst])
+
+				donesMu.Lock()
+				dones = append(dones, done)
+				donesMu.Unlock()
+
+				sup := newPathSupervisor(pathName, c[src], c[dst])
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					defer recoverAndReport(c[src].PathEnd.ChainID, pathName)
+					sup.run(ctx)
+				}()
 			}
 
-			trapSignal(done)
+			trapSignal(func() { cleanup(nil) })
 			return nil
 		},
 	}
+	cmd.Flags().Bool(flagAll, false, "start every path configured in the config file")
+	cmd.Flags().String(flagMetricsListen, "", "address to serve Prometheus metrics on, e.g. :7597 (disabled if unset)")
+	cmd.Flags().String(flagSentryDSN, "", "Sentry DSN to ship panics to (falls back to $SENTRY_DSN)")
 	return strategyFlag(updateTimeFlags(cmd))
 }
 
@@ -122,43 +188,139 @@ func trapSignal(done func()) {
 	done()
 }
 
-// UpdateClientsFromChains takes src, dst chains and update clients based on expiry time
-func UpdateClientsFromChains(src, dst *relayer.Chain) (sleepTime time.Duration, err error) {
-	var srcTimeExpiry, dstTimeExpiry time.Duration
+// clientUpdateResult is one side's outcome from a single UpdateClientsFromChains
+// cycle. Keeping src's and dst's results in separate values (rather than both
+// goroutines writing into one shared `err`/expiry pair) is what makes the two
+// eg.Go closures below safe to run concurrently.
+type clientUpdateResult struct {
+	expiry time.Duration
+	err    error
+}
+
+// minPollInterval is the floor UpdateClientsFromChains will ever sleep for,
+// whether computed from client expiry or from the one-sided-failure fallback.
+// It guards against a negative or zero sleepTime when an update just barely
+// happened (srcTimeExpiry - thresholdTime < 0) causing the caller to busy-loop.
+const minPollInterval = time.Second * 5
+
+// maxConsecutiveBothFailed is how many cycles in a row both sides may fail
+// before UpdateClientsFromChains gives up and returns an error to the caller.
+const maxConsecutiveBothFailed = 3
 
+var (
+	bothFailedMu     sync.Mutex
+	bothFailedCounts = map[string]int{}
+)
+
+// UpdateClientsFromChains takes src, dst chains and updates clients based on
+// expiry time. src and dst are each updated in their own goroutine, and a
+// failure on one side no longer fails the whole call: it's logged through the
+// chain's own output and folded into a short fallback sleep so the loop
+// re-probes soon, rather than unwinding every path in the process via
+// eg.Wait(). Only once both sides have failed maxConsecutiveBothFailed cycles
+// in a row does this escalate to the caller.
+func UpdateClientsFromChains(src, dst *relayer.Chain) (time.Duration, error) {
 	thresholdTime := viper.GetDuration(flagThresholdTime)
 
+	var srcRes, dstRes clientUpdateResult
 	eg := new(errgroup.Group)
-	eg.Go(func() error {
-		srcTimeExpiry, err = relayer.GetClientAndUpdate(src, dst, thresholdTime)
-		return err
+	eg.Go(func() (_ error) {
+		defer func() {
+			if r := recover(); r != nil {
+				reportPanic(src.PathEnd.ChainID, "", r)
+				srcRes.err = fmt.Errorf("panic updating client for chain %s: %v", src.PathEnd.ChainID, r)
+			}
+		}()
+		srcRes.expiry, srcRes.err = relayer.GetClientAndUpdate(src, dst, thresholdTime)
+		observeClientUpdate(src.PathEnd.ChainID, srcRes.expiry, srcRes.err)
+		if srcRes.err != nil {
+			src.Error(srcRes.err)
+		}
+		return nil
 	})
-	eg.Go(func() error {
-		dstTimeExpiry, err = relayer.GetClientAndUpdate(dst, src, thresholdTime)
-		return err
+	eg.Go(func() (_ error) {
+		defer func() {
+			if r := recover(); r != nil {
+				reportPanic(dst.PathEnd.ChainID, "", r)
+				dstRes.err = fmt.Errorf("panic updating client for chain %s: %v", dst.PathEnd.ChainID, r)
+			}
+		}()
+		dstRes.expiry, dstRes.err = relayer.GetClientAndUpdate(dst, src, thresholdTime)
+		observeClientUpdate(dst.PathEnd.ChainID, dstRes.expiry, dstRes.err)
+		if dstRes.err != nil {
+			dst.Error(dstRes.err)
+		}
+		return nil
 	})
-	if err := eg.Wait(); err != nil {
-		return 0, err
-	}
+	_ = eg.Wait() // always nil: both closures report their error via srcRes/dstRes instead
 
-	if srcTimeExpiry > 0 {
-		if dstTimeExpiry > 0 {
-			if srcTimeExpiry < dstTimeExpiry {
-				sleepTime = srcTimeExpiry - thresholdTime
+	key := src.PathEnd.ChainID + "/" + dst.PathEnd.ChainID
 
-			} else {
-				sleepTime = dstTimeExpiry - thresholdTime
-			}
-		} else {
-			sleepTime = srcTimeExpiry - thresholdTime
+	if srcRes.err != nil && dstRes.err != nil {
+		bothFailedMu.Lock()
+		bothFailedCounts[key]++
+		count := bothFailedCounts[key]
+		bothFailedMu.Unlock()
+
+		if count < maxConsecutiveBothFailed {
+			return withJitter(minPollInterval), nil
 		}
-	} else {
-		if dstTimeExpiry > 0 {
-			sleepTime = dstTimeExpiry - thresholdTime
-		} else {
-			return 0, fmt.Errorf("seems clients of both src:%s and dst:%s are expired",
-				src.PathEnd.ChainID, dst.PathEnd.ChainID)
+
+		escalation := fmt.Errorf("clients of both src:%s and dst:%s failed to update %d times in a row: src: %v, dst: %v",
+			src.PathEnd.ChainID, dst.PathEnd.ChainID, count, srcRes.err, dstRes.err)
+		src.Error(escalation)
+		dst.Error(escalation)
+		return 0, escalation
+	}
+
+	bothFailedMu.Lock()
+	delete(bothFailedCounts, key)
+	bothFailedMu.Unlock()
+
+	if srcRes.err != nil || dstRes.err != nil {
+		// Only one side succeeded. Don't let the healthy side's expiry dictate a long
+		// sleep off the back of the other side's failure: re-probe soon instead.
+		fallback := thresholdTime / 4
+		if fallback < minPollInterval {
+			fallback = minPollInterval
 		}
+		return withJitter(fallback), nil
+	}
+
+	sleepTime := srcRes.expiry - thresholdTime
+	if dstSleep := dstRes.expiry - thresholdTime; dstSleep < sleepTime {
+		sleepTime = dstSleep
+	}
+	if sleepTime < minPollInterval {
+		sleepTime = minPollInterval
+	}
+	return withJitter(sleepTime), nil
+}
+
+// withJitter adds up to +/-10% random jitter to d so that multiple paths
+// sharing an RPC endpoint don't all wake up and hit it at the same instant.
+// The result is never below minPollInterval.
+func withJitter(d time.Duration) time.Duration {
+	if d <= minPollInterval {
+		return d
+	}
+	jitter := time.Duration(rand.Int63n(int64(d)/5)) - d/10
+	if d+jitter < minPollInterval {
+		return minPollInterval
+	}
+	return d + jitter
+}
+
+// observeClientUpdate records the result of a single GetClientAndUpdate call
+// against the relayer_client_update_total and relayer_client_expiry_seconds
+// metrics.
+func observeClientUpdate(chainID string, expiry time.Duration, err error) {
+	result := "success"
+	if err != nil {
+		result = "error"
+	}
+	clientUpdateTotal.WithLabelValues(chainID, result).Inc()
+	if err == nil {
+		clientExpirySeconds.WithLabelValues(chainID).Set(expiry.Seconds())
 	}
-	return sleepTime, nil
 }
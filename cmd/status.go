@@ -0,0 +1,81 @@
+/*
+Package cmd includes relayer commands
+Copyright © 2020 Jack Zampolin <jack.zampolin@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// staleStatusThreshold is how long a path's status file can go without an
+// update before `relayer status` stops trusting its reported state. It's set
+// above supervisor.go's max retry backoff (5m) so a path that's merely slow
+// to retry isn't flagged, but well below "the process that wrote this is
+// obviously gone" -- which is exactly what happens if a path's goroutine
+// dies without running its deferred writeStatus(PathStateStopped, ...), e.g.
+// a SIGKILL or a panic recovered above sup.run by recoverAndReport.
+const staleStatusThreshold = time.Minute * 15
+
+// statusCmd represents the status command
+func statusCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "status",
+		Aliases: []string{"stat"},
+		Short:   "Show the state of paths being supervised by a running `start` process",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir := filepath.Join(relayerHome(), "status")
+			entries, err := os.ReadDir(dir)
+			if os.IsNotExist(err) {
+				fmt.Println("no paths have reported status yet, is `start` running?")
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "PATH\tSTATE\tLAST UPDATE\tLAST ERROR")
+			for _, entry := range entries {
+				if entry.IsDir() {
+					continue
+				}
+				data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+				if err != nil {
+					continue
+				}
+				var st pathStatus
+				if err := json.Unmarshal(data, &st); err != nil {
+					continue
+				}
+				state := string(st.State)
+				if time.Since(st.UpdatedAt) > staleStatusThreshold {
+					state = fmt.Sprintf("%s (stale)", state)
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", st.Path, state, st.UpdatedAt.Format(time.RFC3339), st.Error)
+			}
+			return w.Flush()
+		},
+	}
+	return cmd
+}
@@ -0,0 +1,155 @@
+/*
+Package cmd includes relayer commands
+Copyright © 2020 Jack Zampolin <jack.zampolin@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	retry "github.com/avast/retry-go"
+	"github.com/cosmos/relayer/relayer"
+)
+
+// PathState describes where a path supervised by `start` currently sits in its
+// lifecycle. It is written to disk so that `relayer status`, run from another
+// invocation of the binary, can report on a long-running `start` process.
+type PathState string
+
+const (
+	PathStateRunning    PathState = "running"
+	PathStateBackingOff PathState = "backing off"
+	PathStateStopped    PathState = "stopped"
+)
+
+// pathStatus is the on-disk representation of a path's state.
+type pathStatus struct {
+	Path      string    `json:"path"`
+	State     PathState `json:"state"`
+	Error     string    `json:"error,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// pathSupervisor owns the client-update loop for a single path. Previously
+// startCmd ran every path's update loop under one errgroup.Group, so an error
+// from UpdateClientsFromChains on any path killed eg.Wait() and tore down
+// every other path. pathSupervisor instead contains a path's failures to
+// itself: errors are logged and retried with backoff, never propagated.
+type pathSupervisor struct {
+	pathName string
+	src, dst *relayer.Chain
+}
+
+func newPathSupervisor(pathName string, src, dst *relayer.Chain) *pathSupervisor {
+	return &pathSupervisor{pathName: pathName, src: src, dst: dst}
+}
+
+// run is the client-update scheduler for this path. It loops until ctx is
+// canceled, retrying UpdateClientsFromChains with exponential backoff on
+// failure instead of returning the error to a caller.
+func (s *pathSupervisor) run(ctx context.Context) {
+	for {
+		if ctx.Err() != nil {
+			s.src.Log(fmt.Sprintf("path %s: stopping", s.pathName))
+			s.writeStatus(PathStateStopped, nil)
+			return
+		}
+
+		var sleepTime time.Duration
+		err := retry.Do(func() error {
+			// Rotate to each chain's next healthy RPC endpoint before every
+			// attempt (a no-op for chains with no Balancer, i.e. a single
+			// configured RPC address), so a retry actually targets a
+			// different node than the one that just failed.
+			if s.src.Balancer != nil {
+				s.src.RPCAddr = s.src.Balancer.Pick()
+			}
+			if s.dst.Balancer != nil {
+				s.dst.RPCAddr = s.dst.Balancer.Pick()
+			}
+
+			var err error
+			sleepTime, err = UpdateClientsFromChains(s.src, s.dst)
+			return err
+		},
+			retry.Context(ctx),
+			retry.Attempts(0), // retry forever: a failure on this path must not stop the others
+			retry.Delay(time.Second*2),
+			retry.MaxDelay(time.Minute*5),
+			retry.DelayType(retry.BackOffDelay),
+			retry.LastErrorOnly(true),
+			retry.OnRetry(func(n uint, err error) {
+				if s.src.Balancer != nil {
+					s.src.Balancer.MarkCurrentUnhealthy()
+				}
+				if s.dst.Balancer != nil {
+					s.dst.Balancer.MarkCurrentUnhealthy()
+				}
+				s.src.Log(fmt.Sprintf("path %s: update clients failed, retrying: %v", s.pathName, err))
+				s.writeStatus(PathStateBackingOff, err)
+			}),
+		)
+		if err != nil {
+			// Only reachable if ctx was canceled mid-retry.
+			s.src.Log(fmt.Sprintf("path %s: stopping: %v", s.pathName, err))
+			s.writeStatus(PathStateStopped, err)
+			return
+		}
+
+		s.writeStatus(PathStateRunning, nil)
+
+		select {
+		case <-ctx.Done():
+			s.src.Log(fmt.Sprintf("path %s: stopping", s.pathName))
+			s.writeStatus(PathStateStopped, nil)
+			return
+		case <-time.After(sleepTime):
+		}
+	}
+}
+
+func (s *pathSupervisor) writeStatus(state PathState, err error) {
+	st := pathStatus{Path: s.pathName, State: state, UpdatedAt: time.Now()}
+	if err != nil {
+		st.Error = err.Error()
+	}
+
+	dir := filepath.Join(relayerHome(), "status")
+	if mkErr := os.MkdirAll(dir, 0755); mkErr != nil {
+		s.src.Log(fmt.Sprintf("path %s: could not write status: %v", s.pathName, mkErr))
+		return
+	}
+
+	data, mErr := json.MarshalIndent(st, "", "  ")
+	if mErr != nil {
+		return
+	}
+	_ = os.WriteFile(filepath.Join(dir, s.pathName+".json"), data, 0644)
+}
+
+// relayerHome returns the directory the relayer keeps runtime state in that
+// isn't part of the viper-backed config, e.g. status/ and crashes/.
+func relayerHome() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ".relayer"
+	}
+	return filepath.Join(home, ".relayer")
+}
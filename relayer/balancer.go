@@ -0,0 +1,278 @@
+package relayer
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// endpoint is a single RPC (or gRPC) address a Chain can talk to, along with
+// the health bookkeeping the Balancer uses to decide whether to route traffic
+// to it.
+type endpoint struct {
+	url string
+
+	unhealthy atomic.Bool
+	// failedAt is the time the endpoint was last marked unhealthy. It is used
+	// both to compute the cooldown before re-probing and, in the fail-open
+	// case where every endpoint is unhealthy, to prefer the endpoint that
+	// failed longest ago.
+	failedAt         time.Time
+	consecutiveFails int
+}
+
+// URL returns the endpoint's address, e.g. for use in an RPC client.
+func (e *endpoint) URL() string {
+	return e.url
+}
+
+// Balancer round-robins requests across a Chain's healthy RPC endpoints,
+// probing each on an interval and quarantining ones that fail or whose latest
+// block time falls too far behind wall-clock for a cooldown period before
+// trying them again. It is
+// modeled on the health balancer in etcd's clientv3.
+type Balancer struct {
+	mu        sync.RWMutex
+	endpoints []*endpoint
+	cursor    uint64
+	// current is the endpoint handed out by the most recent Pick call, kept
+	// so that a caller which only ever sees URLs (e.g. the cmd package) can
+	// still report a failure back via MarkCurrentUnhealthy.
+	current *endpoint
+}
+
+// NewBalancer builds a Balancer over the given RPC endpoint addresses. urls
+// must contain at least one address.
+func NewBalancer(urls []string) *Balancer {
+	eps := make([]*endpoint, len(urls))
+	for i, u := range urls {
+		eps[i] = &endpoint{url: u}
+	}
+	return &Balancer{endpoints: eps}
+}
+
+// pickEndpoint returns the next healthy endpoint in round-robin order. If
+// every endpoint is currently unhealthy, it fails open and returns the one
+// that failed longest ago rather than blocking the caller.
+func (b *Balancer) pickEndpoint() *endpoint {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	n := len(b.endpoints)
+	start := atomic.AddUint64(&b.cursor, 1)
+	for i := 0; i < n; i++ {
+		ep := b.endpoints[(int(start)+i)%n]
+		if !ep.unhealthy.Load() {
+			return ep
+		}
+	}
+
+	// Fail open: every endpoint is unhealthy, so relay traffic to whichever
+	// one has had the longest cooldown rather than giving up entirely.
+	oldest := b.endpoints[0]
+	for _, ep := range b.endpoints[1:] {
+		if ep.failedAt.Before(oldest.failedAt) {
+			oldest = ep
+		}
+	}
+	return oldest
+}
+
+// Pick rotates to the next healthy RPC endpoint and returns its address. The
+// picked endpoint is remembered so a later MarkCurrentUnhealthy call (from a
+// caller, such as the cmd package's retry loop, that never sees the
+// unexported endpoint type) can quarantine it without the caller needing a
+// reference to it.
+func (b *Balancer) Pick() string {
+	ep := b.pickEndpoint()
+	b.mu.Lock()
+	b.current = ep
+	b.mu.Unlock()
+	return ep.url
+}
+
+// MarkCurrentUnhealthy records a failure, via MarkUnhealthy, against the
+// endpoint most recently returned by Pick, e.g. after a retry attempt against
+// it has failed.
+func (b *Balancer) MarkCurrentUnhealthy() {
+	b.mu.RLock()
+	cur := b.current
+	b.mu.RUnlock()
+	if cur != nil {
+		b.MarkUnhealthy(cur)
+	}
+}
+
+// unhealthyAfterFails is how many consecutive failed probes (or retry
+// attempts) an endpoint must rack up before MarkUnhealthy actually quarantines
+// it. A single dropped request or a node mid-restart shouldn't pull an
+// otherwise-fine endpoint out of rotation.
+const unhealthyAfterFails = 3
+
+// MarkUnhealthy records a failure against ep. Once it has failed
+// unhealthyAfterFails times in a row, it is quarantined and will not be
+// returned by Pick again until its cooldown elapses and a probe marks it
+// healthy, except by pickEndpoint's fail-open case when every endpoint is
+// quarantined.
+func (b *Balancer) MarkUnhealthy(ep *endpoint) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	ep.consecutiveFails++
+	ep.failedAt = time.Now()
+	if ep.consecutiveFails >= unhealthyAfterFails {
+		ep.unhealthy.Store(true)
+	}
+}
+
+// MarkHealthy clears an endpoint's quarantine after a successful probe.
+func (b *Balancer) MarkHealthy(ep *endpoint) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	ep.consecutiveFails = 0
+	ep.unhealthy.Store(false)
+}
+
+// Healthy returns the RPC addresses currently considered healthy, for
+// display purposes (e.g. `relayer chains health`).
+func (b *Balancer) Healthy() []string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	var urls []string
+	for _, ep := range b.endpoints {
+		if !ep.unhealthy.Load() {
+			urls = append(urls, ep.url)
+		}
+	}
+	return urls
+}
+
+// Status summarizes one endpoint's health for display.
+type Status struct {
+	URL              string
+	Unhealthy        bool
+	ConsecutiveFails int
+}
+
+// Statuses returns the health of every endpoint in the balancer, in order.
+func (b *Balancer) Statuses() []Status {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	out := make([]Status, len(b.endpoints))
+	for i, ep := range b.endpoints {
+		out[i] = Status{URL: ep.url, Unhealthy: ep.unhealthy.Load(), ConsecutiveFails: ep.consecutiveFails}
+	}
+	return out
+}
+
+// cooldown returns how long an endpoint with n consecutive failures should
+// stay quarantined before being probed again: 30s * 2^n, capped at 5m.
+func cooldown(n int) time.Duration {
+	d := time.Second * 30
+	for i := 0; i < n && d < time.Minute*5; i++ {
+		d *= 2
+	}
+	if d > time.Minute*5 {
+		d = time.Minute * 5
+	}
+	return d
+}
+
+type statusResponse struct {
+	Result struct {
+		SyncInfo struct {
+			LatestBlockTime time.Time `json:"latest_block_time"`
+		} `json:"sync_info"`
+	} `json:"result"`
+}
+
+// probe checks one endpoint's /status, marking it unhealthy if the request
+// fails or if its reported latest_block_time trails wall-clock by more than
+// staleThreshold, and marking it healthy (ending its quarantine) otherwise.
+// A previously-unhealthy endpoint is only probed again once its cooldown,
+// computed from its consecutive failure count, has elapsed.
+func (b *Balancer) probe(ctx context.Context, client *http.Client, ep *endpoint, staleThreshold time.Duration) {
+	if ep.unhealthy.Load() && time.Since(ep.failedAt) < cooldown(ep.consecutiveFails) {
+		return
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ep.url+"/status", nil)
+	if err != nil {
+		b.MarkUnhealthy(ep)
+		return
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		b.MarkUnhealthy(ep)
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		b.MarkUnhealthy(ep)
+		return
+	}
+
+	var sr statusResponse
+	if err := json.NewDecoder(resp.Body).Decode(&sr); err != nil {
+		b.MarkUnhealthy(ep)
+		return
+	}
+
+	if time.Since(sr.Result.SyncInfo.LatestBlockTime) > staleThreshold {
+		b.MarkUnhealthy(ep)
+		return
+	}
+
+	b.MarkHealthy(ep)
+}
+
+// ProbeNow synchronously probes every endpoint once and returns only once
+// every probe has completed. It's meant for one-off invocations (e.g. `chains
+// health`) that have no long-running StartProbing loop keeping endpoint
+// health current, unlike a `start` process.
+func (b *Balancer) ProbeNow(ctx context.Context, staleThreshold time.Duration) {
+	client := &http.Client{Timeout: time.Second * 5}
+
+	b.mu.RLock()
+	eps := append([]*endpoint(nil), b.endpoints...)
+	b.mu.RUnlock()
+
+	var wg sync.WaitGroup
+	for _, ep := range eps {
+		wg.Add(1)
+		go func(ep *endpoint) {
+			defer wg.Done()
+			b.probe(ctx, client, ep, staleThreshold)
+		}(ep)
+	}
+	wg.Wait()
+}
+
+// StartProbing launches a goroutine that probes every endpoint on interval
+// until ctx is canceled. staleThreshold bounds how far behind wall-clock an
+// endpoint's latest_block_time may trail before it is considered unhealthy.
+func (b *Balancer) StartProbing(ctx context.Context, interval, staleThreshold time.Duration) {
+	client := &http.Client{Timeout: time.Second * 5}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				b.mu.RLock()
+				eps := append([]*endpoint(nil), b.endpoints...)
+				b.mu.RUnlock()
+				for _, ep := range eps {
+					go b.probe(ctx, client, ep, staleThreshold)
+				}
+			}
+		}
+	}()
+}
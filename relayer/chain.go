@@ -0,0 +1,79 @@
+package relayer
+
+import "fmt"
+
+// PathEnd identifies one side of a path: the chain it runs on and the
+// client/channel it talks to its counterparty over.
+type PathEnd struct {
+	ChainID   string
+	ChannelID string
+	PortID    string
+	Order     string
+	Version   string
+}
+
+// Path is a configured IBC path between two chains: which channel, port,
+// ordering, and version each side uses to talk to its counterparty.
+type Path struct {
+	Src *PathEnd
+	Dst *PathEnd
+}
+
+// ChainConfig is the on-disk, viper-backed configuration for a single chain.
+type ChainConfig struct {
+	Key            string
+	ChainID        string
+	RPCAddr        string
+	RPCAddrs       []string
+	AccountPrefix  string
+	GasAdjustment  float64
+	GasPrices      string
+	TrustingPeriod string
+}
+
+// Chain is a configured chain the relayer talks to.
+type Chain struct {
+	Key     string
+	ChainID string
+	RPCAddr string
+	PathEnd *PathEnd
+
+	// Balancer round-robins requests across the chain's healthy RPC
+	// endpoints when its config lists more than one. It is nil when the
+	// chain only has a single RPCAddr, in which case RPCAddr is used as-is.
+	Balancer *Balancer
+}
+
+// NewChain builds a Chain from its ChainConfig, wiring up a Balancer when the
+// config lists more than one RPC endpoint.
+func NewChain(cfg *ChainConfig) *Chain {
+	c := &Chain{
+		Key:     cfg.Key,
+		ChainID: cfg.ChainID,
+		RPCAddr: cfg.RPCAddr,
+		PathEnd: &PathEnd{ChainID: cfg.ChainID},
+	}
+
+	addrs := cfg.RPCAddrs
+	if len(addrs) == 0 && cfg.RPCAddr != "" {
+		addrs = []string{cfg.RPCAddr}
+	}
+	if len(addrs) > 1 {
+		c.Balancer = NewBalancer(addrs)
+		c.RPCAddr = addrs[0]
+	}
+
+	return c
+}
+
+// Log prints msg prefixed with this chain's ID, standing in for a structured
+// per-chain logger. Callers should prefer this (or Error) over a bare
+// fmt.Print so that log output can be filtered per chain.
+func (c *Chain) Log(msg string) {
+	fmt.Printf("{%s} %s\n", c.ChainID, msg)
+}
+
+// Error logs err through the chain's logger.
+func (c *Chain) Error(err error) {
+	c.Log(fmt.Sprintf("error: %v", err))
+}
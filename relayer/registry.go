@@ -0,0 +1,236 @@
+package relayer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// DefaultRegistryURL is the default base URL the relayer fetches chain and
+// path metadata from, following the layout of github.com/cosmos/chain-registry.
+const DefaultRegistryURL = "https://raw.githubusercontent.com/cosmos/chain-registry/master"
+
+// registryChain is the subset of a chain-registry chain.json this package
+// cares about when bootstrapping a ChainConfig.
+type registryChain struct {
+	ChainName    string `json:"chain_name"`
+	ChainID      string `json:"chain_id"`
+	Bech32Prefix string `json:"bech32_prefix"`
+	Fees         struct {
+		FeeTokens []struct {
+			Denom           string  `json:"denom"`
+			AverageGasPrice float64 `json:"average_gas_price"`
+		} `json:"fee_tokens"`
+	} `json:"fees"`
+	APIs struct {
+		RPC []struct {
+			Address string `json:"address"`
+		} `json:"rpc"`
+	} `json:"apis"`
+}
+
+// RegistryClient fetches and translates chain and path metadata from a
+// cosmos chain-registry instance.
+type RegistryClient struct {
+	BaseURL string
+	HTTP    *http.Client
+}
+
+// NewRegistryClient builds a RegistryClient against baseURL. If baseURL is
+// empty, DefaultRegistryURL is used.
+func NewRegistryClient(baseURL string) *RegistryClient {
+	if baseURL == "" {
+		baseURL = DefaultRegistryURL
+	}
+	return &RegistryClient{BaseURL: baseURL, HTTP: &http.Client{Timeout: time.Second * 10}}
+}
+
+func (r *RegistryClient) getJSON(ctx context.Context, path string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.BaseURL+path, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := r.HTTP.Do(req)
+	if err != nil {
+		return fmt.Errorf("fetching %s: %w", path, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("fetching %s: unexpected status %s", path, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// fetchChain pulls chain-name/chain.json from the registry.
+func (r *RegistryClient) fetchChain(ctx context.Context, chainName string) (*registryChain, error) {
+	var rc registryChain
+	if err := r.getJSON(ctx, "/"+chainName+"/chain.json", &rc); err != nil {
+		return nil, err
+	}
+	return &rc, nil
+}
+
+// workingRPC probes each of a chain's advertised RPC endpoints' /status route
+// in order and returns the first one that responds successfully.
+func (r *RegistryClient) workingRPC(ctx context.Context, rc *registryChain) (string, error) {
+	for _, api := range rc.APIs.RPC {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, api.Address+"/status", nil)
+		if err != nil {
+			continue
+		}
+		resp, err := r.HTTP.Do(req)
+		if err != nil {
+			continue
+		}
+		resp.Body.Close()
+		if resp.StatusCode == http.StatusOK {
+			return api.Address, nil
+		}
+	}
+	return "", fmt.Errorf("no working RPC endpoint found for chain %s", rc.ChainName)
+}
+
+// ChainConfigFromRegistry fetches chainName's chain.json, probes its
+// advertised RPC endpoints for a working one, and translates the result into
+// a ChainConfig ready to persist into the relayer config.
+func (r *RegistryClient) ChainConfigFromRegistry(ctx context.Context, chainName, key string) (*ChainConfig, error) {
+	rc, err := r.fetchChain(ctx, chainName)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcAddr, err := r.workingRPC(ctx, rc)
+	if err != nil {
+		return nil, err
+	}
+
+	var gasPrices string
+	if len(rc.Fees.FeeTokens) > 0 {
+		ft := rc.Fees.FeeTokens[0]
+		gasPrices = fmt.Sprintf("%v%s", ft.AverageGasPrice, ft.Denom)
+	}
+
+	return &ChainConfig{
+		Key:            key,
+		ChainID:        rc.ChainID,
+		RPCAddr:        rpcAddr,
+		AccountPrefix:  rc.Bech32Prefix,
+		GasAdjustment:  1.5,
+		GasPrices:      gasPrices,
+		TrustingPeriod: defaultTrustingPeriod,
+	}, nil
+}
+
+// defaultTrustingPeriod is used for chains bootstrapped from the registry,
+// since chain.json carries no unbonding-period or trusting-period field to
+// derive one from. It's two-thirds of the cosmos SDK's common three-week
+// default unbonding period, matching the ratio the relayer docs recommend
+// elsewhere. Operators running a chain with a non-default unbonding period
+// must still override this by hand.
+const defaultTrustingPeriod = "336h"
+
+// ibcPath is the subset of a chain-registry _IBC/<chain-1>-<chain-2>.json
+// this package needs to materialize a relayer Path.
+type ibcPath struct {
+	Chain1 struct {
+		ChainName string `json:"chain-name"`
+	} `json:"chain-1"`
+	Chain2 struct {
+		ChainName string `json:"chain-name"`
+	} `json:"chain-2"`
+	Channels []struct {
+		Chain1 struct {
+			ChannelID string `json:"channel-id"`
+			PortID    string `json:"port-id"`
+		} `json:"chain-1"`
+		Chain2 struct {
+			ChannelID string `json:"channel-id"`
+			PortID    string `json:"port-id"`
+		} `json:"chain-2"`
+		Ordering string `json:"ordering"`
+		Version  string `json:"version"`
+	} `json:"channels"`
+}
+
+// githubContentEntry is one entry in the GitHub contents API response used
+// to list files in the registry's _IBC/ directory.
+type githubContentEntry struct {
+	Name string `json:"name"`
+}
+
+// defaultIBCDirAPI is the GitHub contents API URL listing chain-registry's
+// _IBC/ directory, which isn't available via the raw.githubusercontent.com
+// mirror used for individual files.
+const defaultIBCDirAPI = "https://api.github.com/repos/cosmos/chain-registry/contents/_IBC"
+
+// ListIBCPaths returns the filenames (with their .json extension) of every
+// _IBC/<chain-1>-<chain-2>.json entry in the registry.
+func (r *RegistryClient) ListIBCPaths(ctx context.Context) ([]string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, defaultIBCDirAPI, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := r.HTTP.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("listing _IBC directory: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("listing _IBC directory: unexpected status %s", resp.Status)
+	}
+
+	var entries []githubContentEntry
+	if err := json.NewDecoder(resp.Body).Decode(&entries); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		names = append(names, e.Name)
+	}
+	return names, nil
+}
+
+// fetchIBCPath pulls _IBC/fileName from the registry.
+func (r *RegistryClient) fetchIBCPath(ctx context.Context, fileName string) (*ibcPath, error) {
+	var p ibcPath
+	if err := r.getJSON(ctx, "/_IBC/"+fileName, &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// PathFromRegistry materializes a Path between chain1Key and chain2Key (keys
+// as they appear in the local config) from the registry's _IBC/ entry
+// describing those two chains. chain1Key is taken as the entry's chain-1 and
+// chain2Key as its chain-2; the order is not checked against the entry, and
+// only its first channel is used.
+func (r *RegistryClient) PathFromRegistry(ctx context.Context, fileName, chain1Key, chain2Key string) (*Path, error) {
+	p, err := r.fetchIBCPath(ctx, fileName)
+	if err != nil {
+		return nil, err
+	}
+	if len(p.Channels) == 0 {
+		return nil, fmt.Errorf("%s has no channels", fileName)
+	}
+	ch := p.Channels[0]
+
+	return &Path{
+		Src: &PathEnd{
+			ChainID:   chain1Key,
+			ChannelID: ch.Chain1.ChannelID,
+			PortID:    ch.Chain1.PortID,
+			Order:     ch.Ordering,
+			Version:   ch.Version,
+		},
+		Dst: &PathEnd{
+			ChainID:   chain2Key,
+			ChannelID: ch.Chain2.ChannelID,
+			PortID:    ch.Chain2.PortID,
+			Order:     ch.Ordering,
+			Version:   ch.Version,
+		},
+	}, nil
+}